@@ -0,0 +1,613 @@
+package goarabic
+
+import "strings"
+
+// Direction is the base (paragraph) direction passed to ReorderBidi.
+type Direction int
+
+const (
+	// LTR forces a left-to-right base direction.
+	LTR Direction = iota
+	// RTL forces a right-to-left base direction.
+	RTL
+	// Auto derives the base direction from the first strong character in
+	// the text (P2/P3 of the Unicode Bidirectional Algorithm), defaulting
+	// to LTR if none is found.
+	Auto
+)
+
+// bidiClass is a Unicode bidirectional character type, as defined by UAX #9.
+type bidiClass int
+
+const (
+	classL bidiClass = iota
+	classR
+	classAL
+	classEN
+	classES
+	classET
+	classAN
+	classCS
+	classNSM
+	classBN
+	classB
+	classS
+	classWS
+	classON
+	classLRE
+	classRLE
+	classPDF
+	classLRI
+	classRLI
+	classFSI
+	classPDI
+)
+
+// classify assigns a rune its Unicode bidirectional class, covering the
+// Arabic, Hebrew, Latin and common-punctuation ranges this module handles.
+func classify(r rune) bidiClass {
+	switch {
+	case r == '\u202a':
+		return classLRE
+	case r == '\u202b':
+		return classRLE
+	case r == '\u202c':
+		return classPDF
+	case r == '\u2066':
+		return classLRI
+	case r == '\u2067':
+		return classRLI
+	case r == '\u2068':
+		return classFSI
+	case r == '\u2069':
+		return classPDI
+	case r == '\n' || r == '\r' || r == '\u2029':
+		return classB
+	case r == '\t' || r == '\v':
+		return classS
+	case r == ' ' || r == '\f' || r == '\u00a0':
+		return classWS
+	case tashkeel[r]:
+		return classNSM
+	case r >= '0' && r <= '9':
+		return classEN
+	case r >= '\u0660' && r <= '\u0669': // Arabic-Indic digits
+		return classAN
+	case r >= '\u06f0' && r <= '\u06f9': // Extended (Persian) digits
+		return classAN
+	case r == '\u066c' || r == '\u066b': // Arabic thousands/decimal separators
+		return classAN
+	case r == ',' || r == '.' || r == ':' || r == '/':
+		return classCS
+	case r == '+' || r == '-':
+		return classES
+	case r == '%' || r == '$' || r == '#':
+		return classET
+	case isArabicLetter(r):
+		return classAL
+	case r >= '\u0590' && r <= '\u05ff': // Hebrew
+		return classR
+	case r >= '\ufb1d' && r <= '\ufb4f': // Hebrew presentation forms
+		return classR
+	case (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z'):
+		return classL
+	case r >= '\u00c0' && r <= '\u024f': // Latin supplement/extended
+		return classL
+	case isUnicodeSpace(r):
+		return classWS
+	default:
+		return classON
+	}
+}
+
+// isArabicLetter reports whether r is an Arabic letter (as opposed to an
+// Arabic digit or punctuation, which get their own classes above).
+func isArabicLetter(r rune) bool {
+	switch {
+	case r >= '\u0621' && r <= '\u064a':
+		return true
+	case r >= '\u066e' && r <= '\u06d3':
+		return true
+	case r >= '\u06d5' && r <= '\u06ff':
+		return true
+	case r >= '\ufb50' && r <= '\ufdff':
+		return true
+	case r >= '\ufe70' && r <= '\ufeff':
+		return true
+	default:
+		return false
+	}
+}
+
+func isUnicodeSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\f' || r == '\v'
+}
+
+// ReorderBidi reorders text from logical to visual order following the
+// Unicode Bidirectional Algorithm (UAX #9): it assigns each rune a bidi
+// class and an embedding level (resolving explicit embeddings, then the
+// weak rules W1-W7, the neutral rules N1-N2 and the implicit rules I1-I2),
+// then reverses contiguous runs of equal level from the highest level down
+// to 1 (L1-L2). Line breaking is a separate pass (see FixBidiText) so
+// callers can wrap logical-order text first and reorder per visual line.
+func ReorderBidi(text string, baseDir Direction) string {
+	runes := []rune(text)
+	if len(runes) == 0 {
+		return text
+	}
+
+	classes := make([]bidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = classify(r)
+	}
+	original := append([]bidiClass(nil), classes...)
+
+	paragraphLevel := resolveParagraphLevel(baseDir, classes)
+	levels := resolveExplicitLevels(classes, paragraphLevel)
+	resolveWeakTypes(classes, levels)
+	resolveNeutralTypes(classes, levels, paragraphLevel)
+	resolveImplicitLevels(classes, levels)
+	resetSeparatorLevels(original, levels, paragraphLevel)
+	mirrored := mirrorRunes(runes, levels)
+
+	return reorderByLevel(mirrored, levels, paragraphLevel)
+}
+
+// mirrorPairs holds the paired punctuation L4 mirrors: each has a
+// Bidi_Mirrored glyph that should be swapped for its counterpart wherever
+// it ends up at an R (odd) resolved level.
+var mirrorPairs = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+	'‹': '›', '›': '‹',
+}
+
+// mirrorRunes implements L4: a character is depicted by its mirrored glyph
+// when its resolved level is R (odd), so that e.g. a "(...)" span embedded
+// in an RTL run still opens and closes on the visually correct side once
+// L2 reverses it.
+func mirrorRunes(runes []rune, levels []int) []rune {
+	out := make([]rune, len(runes))
+	copy(out, runes)
+	for i, l := range levels {
+		if l%2 == 1 {
+			if m, ok := mirrorPairs[out[i]]; ok {
+				out[i] = m
+			}
+		}
+	}
+	return out
+}
+
+// resolveParagraphLevel implements P2/P3: pick the paragraph embedding
+// level from the base direction, or from the first strong character when
+// baseDir is Auto.
+func resolveParagraphLevel(baseDir Direction, classes []bidiClass) int {
+	switch baseDir {
+	case LTR:
+		return 0
+	case RTL:
+		return 1
+	default:
+		for _, c := range classes {
+			switch c {
+			case classL:
+				return 0
+			case classR, classAL:
+				return 1
+			}
+		}
+		return 0
+	}
+}
+
+// resolveExplicitLevels implements a simplified X1-X8: it maintains a
+// directional status stack for LRE/RLE/LRI/RLI/FSI/PDF/PDI, assigns each
+// rune the level in effect when it was scanned, and marks the embedding and
+// isolate initiators and PDF as BN (removed at X9, left in place for L1);
+// PDI keeps its own class.
+func resolveExplicitLevels(classes []bidiClass, paragraphLevel int) []int {
+	levels := make([]int, len(classes))
+
+	type entry struct {
+		level    int
+		override bidiClass // classON means no override
+	}
+	stack := []entry{{level: paragraphLevel, override: classON}}
+	top := func() entry { return stack[len(stack)-1] }
+
+	nextOddLevel := func(l int) int {
+		if l%2 == 0 {
+			return l + 1
+		}
+		return l + 2
+	}
+	nextEvenLevel := func(l int) int {
+		if l%2 == 0 {
+			return l + 2
+		}
+		return l + 1
+	}
+
+	for i, c := range classes {
+		switch c {
+		case classRLE, classLRE, classRLI, classLRI, classFSI:
+			levels[i] = top().level
+			var newLevel int
+			if c == classRLE || c == classRLI {
+				newLevel = nextOddLevel(top().level)
+			} else {
+				newLevel = nextEvenLevel(top().level)
+			}
+			if c == classRLI || c == classLRI || c == classFSI {
+				levels[i] = top().level // isolate initiator keeps outer level
+			}
+			if len(stack) < 125 {
+				stack = append(stack, entry{level: newLevel, override: classON})
+			}
+			classes[i] = classBN
+		case classPDF, classPDI:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			levels[i] = top().level
+			if c == classPDF {
+				classes[i] = classBN
+			}
+		default:
+			levels[i] = top().level
+			if top().override != classON {
+				classes[i] = top().override
+			}
+		}
+	}
+
+	return levels
+}
+
+// resolveWeakTypes implements W1-W7 per isolating run (approximated here as
+// per maximal run of equal level, which matches the common, non-nested
+// case this module targets).
+func resolveWeakTypes(classes []bidiClass, levels []int) {
+	forEachRun(levels, func(start, end int) {
+		// W1: NSM takes the type of the previous character (or sot -> ON).
+		prev := classON
+		for i := start; i < end; i++ {
+			if classes[i] == classNSM {
+				if prev == classLRE || prev == classRLE || prev == classPDF ||
+					prev == classLRI || prev == classRLI || prev == classFSI || prev == classPDI {
+					classes[i] = classON
+				} else {
+					classes[i] = prev
+				}
+			}
+			prev = classes[i]
+		}
+
+		// W2: EN becomes AN if the last strong type was AL.
+		lastStrong := classL
+		for i := start; i < end; i++ {
+			switch classes[i] {
+			case classL, classR, classAL:
+				lastStrong = classes[i]
+			case classEN:
+				if lastStrong == classAL {
+					classes[i] = classAN
+				}
+			}
+		}
+
+		// W3: AL becomes R.
+		for i := start; i < end; i++ {
+			if classes[i] == classAL {
+				classes[i] = classR
+			}
+		}
+
+		// W4: a single ES/CS between two ENs (or CS between two ANs) takes their type.
+		for i := start + 1; i < end-1; i++ {
+			if classes[i] == classES && classes[i-1] == classEN && classes[i+1] == classEN {
+				classes[i] = classEN
+			}
+			if classes[i] == classCS {
+				if classes[i-1] == classEN && classes[i+1] == classEN {
+					classes[i] = classEN
+				} else if classes[i-1] == classAN && classes[i+1] == classAN {
+					classes[i] = classAN
+				}
+			}
+		}
+
+		// W5: a sequence of ET adjacent to EN takes EN.
+		for i := start; i < end; i++ {
+			if classes[i] != classET {
+				continue
+			}
+			j := i
+			for j < end && classes[j] == classET {
+				j++
+			}
+			touchesEN := (i > start && classes[i-1] == classEN) || (j < end && classes[j] == classEN)
+			if touchesEN {
+				for k := i; k < j; k++ {
+					classes[k] = classEN
+				}
+			}
+			i = j - 1
+		}
+
+		// W6: remaining ES/ET/CS become ON.
+		for i := start; i < end; i++ {
+			if classes[i] == classES || classes[i] == classET || classes[i] == classCS {
+				classes[i] = classON
+			}
+		}
+
+		// W7: EN becomes L if the last strong type was L.
+		lastStrong = classL
+		for i := start; i < end; i++ {
+			switch classes[i] {
+			case classL, classR:
+				lastStrong = classes[i]
+			case classEN:
+				if lastStrong == classL {
+					classes[i] = classL
+				}
+			}
+		}
+	})
+}
+
+// resolveNeutralTypes implements N1-N2: runs of NI (neutral or isolate
+// formatting) characters take the surrounding strong direction when it
+// matches on both sides, otherwise the embedding direction.
+func resolveNeutralTypes(classes []bidiClass, levels []int, paragraphLevel int) {
+	isNI := func(c bidiClass) bool {
+		switch c {
+		case classB, classS, classWS, classON, classBN, classLRE, classRLE, classPDF, classLRI, classRLI, classFSI, classPDI:
+			return true
+		default:
+			return false
+		}
+	}
+	strongOf := func(c bidiClass) bidiClass {
+		switch c {
+		case classEN, classAN:
+			return classR
+		case classL:
+			return classL
+		case classR:
+			return classR
+		default:
+			return classON
+		}
+	}
+
+	forEachRun(levels, func(start, end int) {
+		i := start
+		for i < end {
+			if !isNI(classes[i]) {
+				i++
+				continue
+			}
+			j := i
+			for j < end && isNI(classes[j]) {
+				j++
+			}
+
+			before := classL
+			if i > start {
+				before = strongOf(classes[i-1])
+			} else if levels[start]%2 == 1 {
+				before = classR
+			}
+
+			after := classL
+			if j < end {
+				after = strongOf(classes[j])
+			} else if levels[start]%2 == 1 {
+				after = classR
+			}
+
+			var resolved bidiClass
+			if before == after && (before == classL || before == classR) {
+				resolved = before
+			} else if levels[start]%2 == 1 {
+				resolved = classR
+			} else {
+				resolved = classL
+			}
+
+			for k := i; k < j; k++ {
+				classes[k] = resolved
+			}
+			i = j
+		}
+	})
+}
+
+// resolveImplicitLevels implements I1-I2: bumps each character's level
+// according to its (already resolved to L/R/EN/AN) type and the parity of
+// its current level.
+func resolveImplicitLevels(classes []bidiClass, levels []int) {
+	for i, c := range classes {
+		even := levels[i]%2 == 0
+		switch c {
+		case classL:
+			if !even {
+				levels[i]++
+			}
+		case classR:
+			if even {
+				levels[i]++
+			}
+		case classEN, classAN:
+			if even {
+				levels[i] += 2
+			} else {
+				levels[i]++
+			}
+		}
+	}
+}
+
+// resetSeparatorLevels implements (part of) L1 over the classes as classify
+// assigned them: segment separators, paragraph separators and any trailing
+// whitespace are reset to the paragraph embedding level.
+func resetSeparatorLevels(classes []bidiClass, levels []int, paragraphLevel int) {
+	trailing := true
+	for i := len(classes) - 1; i >= 0; i-- {
+		switch classes[i] {
+		case classS, classB:
+			levels[i] = paragraphLevel
+		case classWS, classBN, classLRE, classRLE, classPDF, classLRI, classRLI, classFSI, classPDI:
+			if trailing {
+				levels[i] = paragraphLevel
+			}
+		default:
+			trailing = false
+		}
+	}
+}
+
+// forEachRun calls fn with the [start, end) bounds of every maximal run of
+// equal embedding level.
+func forEachRun(levels []int, fn func(start, end int)) {
+	if len(levels) == 0 {
+		return
+	}
+	start := 0
+	for i := 1; i <= len(levels); i++ {
+		if i == len(levels) || levels[i] != levels[start] {
+			fn(start, i)
+			start = i
+		}
+	}
+}
+
+// reorderByLevel implements L2: reverses contiguous runs of characters at
+// each level from the highest level down to 1 (or to the lowest odd
+// level), producing the final visual order.
+func reorderByLevel(runes []rune, levels []int, paragraphLevel int) string {
+	out := make([]rune, len(runes))
+	copy(out, runes)
+
+	maxLevel := 0
+	minOdd := -1
+	for _, l := range levels {
+		if l > maxLevel {
+			maxLevel = l
+		}
+		if l%2 == 1 && (minOdd == -1 || l < minOdd) {
+			minOdd = l
+		}
+	}
+	if minOdd == -1 {
+		return string(out)
+	}
+
+	for level := maxLevel; level >= minOdd; level-- {
+		i := 0
+		for i < len(levels) {
+			if levels[i] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(levels) && levels[j] >= level {
+				j++
+			}
+			reverseRunes(out[i:j])
+			i = j
+		}
+	}
+
+	return string(out)
+}
+
+func reverseRunes(r []rune) {
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+}
+
+// FixBidiText shapes and reorders Arabic text for visual display: it
+// shapes each Arabic word with ToGlyphWithOptions, lam-alef ligatures
+// included (shaping runs on logical order),
+// optionally wraps the text into lines of at most maxCharsPerLine
+// characters, then reorders each line into visual order with ReorderBidi.
+func FixBidiText(text string, maxCharsPerLine int) string {
+	if len(text) == 0 {
+		return text
+	}
+
+	var lines []string
+	if maxCharsPerLine > 0 {
+		lines = splitIntoLinesByChars(text, maxCharsPerLine)
+	} else {
+		lines = []string{text}
+	}
+
+	processedLines := make([]string, len(lines))
+	for i, line := range lines {
+		shaped := shapeArabicWords(line)
+		processedLines[i] = ReorderBidi(shaped, Auto)
+	}
+
+	return strings.Join(processedLines, "\n")
+}
+
+// shapeArabicWords runs ToGlyphWithOptions (with lam-alef ligatures enabled,
+// its default) over every word of line that contains an Arabic letter,
+// leaving numeric and non-Arabic words untouched.
+func shapeArabicWords(line string) string {
+	words := strings.Fields(line)
+	for i, word := range words {
+		for _, r := range word {
+			if isArabicLetter(r) {
+				words[i] = ToGlyphWithOptions(word, Options{})
+				break
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// isNumeric reports whether r is a digit (Western or Arabic).
+func isNumeric(r rune) bool {
+	return (r >= '0' && r <= '9') || (r >= '\u0660' && r <= '\u0669')
+}
+
+func splitIntoLinesByChars(text string, maxChars int) []string {
+	var lines []string
+	var currentLine strings.Builder
+	currentLineCount := 0
+
+	words := strings.Fields(text)
+	for _, word := range words {
+		wordLen := len([]rune(word)) + 1 // +1 for space
+
+		if currentLineCount > 0 && currentLineCount+wordLen > maxChars {
+			lines = append(lines, strings.TrimSpace(currentLine.String()))
+			currentLine.Reset()
+			currentLineCount = 0
+		}
+
+		if currentLineCount == 0 {
+			currentLine.WriteString(word)
+			currentLineCount = len([]rune(word))
+		} else {
+			currentLine.WriteString(" " + word)
+			currentLineCount += wordLen
+		}
+	}
+
+	if currentLine.Len() > 0 {
+		lines = append(lines, strings.TrimSpace(currentLine.String()))
+	}
+
+	return lines
+}
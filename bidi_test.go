@@ -0,0 +1,44 @@
+package goarabic
+
+import "testing"
+
+func TestReorderBidi(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		baseDir Direction
+		want    string
+	}{
+		{"pure RTL word", "مرحبا", Auto, "ابحرم"},
+		{"RTL with a number between words", "مرحبا 123 بالعالم", Auto, "ملاعلاب 123 ابحرم"},
+		{"LTR base with an embedded RTL word", "hello مرحبا world", LTR, "hello ابحرم world"},
+		{"RTL base with an embedded RTL word", "hello مرحبا world", RTL, "world ابحرم hello"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := ReorderBidi(c.in, c.baseDir); got != c.want {
+				t.Errorf("ReorderBidi(%q, %v) = %q, want %q", c.in, c.baseDir, got, c.want)
+			}
+		})
+	}
+}
+
+// TestReorderBidiMirrorsBrackets covers L4: paired punctuation that ends up
+// at an R (odd) resolved level must be mirrored so it still visually opens
+// and closes on the correct side once L2 reverses the run.
+func TestReorderBidiMirrorsBrackets(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"مرحبا (بك) يا صديقي", "يقيدص اي (كب) ابحرم"},
+		{"abc [def] ghi", "abc [def] ghi"}, // pure LTR: nothing is at an R level, so no mirroring
+	}
+
+	for _, c := range cases {
+		if got := ReorderBidi(c.in, Auto); got != c.want {
+			t.Errorf("ReorderBidi(%q, Auto) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
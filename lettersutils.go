@@ -0,0 +1,133 @@
+package goarabic
+
+// Harf (letter in Arabic) is a construct that holds the 4 shapes
+// (Unicode/Isolated/Beggining/Medium/Final) of an Arabic presentation-form letter.
+type Harf struct {
+	Unicode   rune
+	Isolated  rune
+	Beggining rune
+	Medium    rune
+	Final     rune
+}
+
+// tashkeel holds the Arabic vowel marks (Tashkeel) that SmartLength and
+// RemoveTashkeel skip over.
+var tashkeel = map[rune]bool{
+	'ً': true, // FATHATAN
+	'ٌ': true, // DAMMATAN
+	'ٍ': true, // KASRATAN
+	'َ': true, // FATHA
+	'ُ': true, // DAMMA
+	'ِ': true, // KASRA
+	'ّ': true, // SHADDA
+	'ْ': true, // SUKUN
+	'ٓ': true, // MADDAH ABOVE
+	'ٔ': true, // HAMZA ABOVE
+	'ٕ': true, // HAMZA BELOW
+	'ٰ': true, // SUPERSCRIPT ALEF
+}
+
+// TATWEEL is the Arabic Tatweel (kashida) elongation character.
+var TATWEEL = Harf{Unicode: 'ـ', Isolated: 'ـ', Beggining: 'ـ', Medium: 'ـ', Final: 'ـ'}
+
+// alphabet holds the 4 shapes of every Arabic letter, keyed by its Unicode codepoint.
+var alphabet = []Harf{
+	{Unicode: 'ء', Isolated: 'ﺀ'},                                          // hamza
+	{Unicode: 'آ', Isolated: 'ﺁ', Final: 'ﺂ'},                              // alef madda
+	{Unicode: 'أ', Isolated: 'ﺃ', Final: 'ﺄ'},                              // alef hamza above
+	{Unicode: 'ؤ', Isolated: 'ﺅ', Final: 'ﺆ'},                              // waw hamza
+	{Unicode: 'إ', Isolated: 'ﺇ', Final: 'ﺈ'},                              // alef hamza below
+	{Unicode: 'ئ', Isolated: 'ﺉ', Beggining: 'ﺋ', Medium: 'ﺌ', Final: 'ﺊ'}, // yeh hamza
+	{Unicode: 'ا', Isolated: 'ﺍ', Final: 'ﺎ'},                              // alef
+	{Unicode: 'ب', Isolated: 'ﺏ', Beggining: 'ﺑ', Medium: 'ﺒ', Final: 'ﺐ'}, // beh
+	{Unicode: 'ة', Isolated: 'ﺓ', Final: 'ﺔ'},                              // teh marbuta
+	{Unicode: 'ت', Isolated: 'ﺕ', Beggining: 'ﺗ', Medium: 'ﺘ', Final: 'ﺖ'}, // teh
+	{Unicode: 'ث', Isolated: 'ﺙ', Beggining: 'ﺛ', Medium: 'ﺜ', Final: 'ﺚ'}, // theh
+	{Unicode: 'ج', Isolated: 'ﺝ', Beggining: 'ﺟ', Medium: 'ﺠ', Final: 'ﺞ'}, // jeem
+	{Unicode: 'ح', Isolated: 'ﺡ', Beggining: 'ﺣ', Medium: 'ﺤ', Final: 'ﺢ'}, // hah
+	{Unicode: 'خ', Isolated: 'ﺥ', Beggining: 'ﺧ', Medium: 'ﺨ', Final: 'ﺦ'}, // khah
+	{Unicode: 'د', Isolated: 'ﺩ', Final: 'ﺪ'},                              // dal
+	{Unicode: 'ذ', Isolated: 'ﺫ', Final: 'ﺬ'},                              // thal
+	{Unicode: 'ر', Isolated: 'ﺭ', Final: 'ﺮ'},                              // reh
+	{Unicode: 'ز', Isolated: 'ﺯ', Final: 'ﺰ'},                              // zain
+	{Unicode: 'س', Isolated: 'ﺱ', Beggining: 'ﺳ', Medium: 'ﺴ', Final: 'ﺲ'}, // seen
+	{Unicode: 'ش', Isolated: 'ﺵ', Beggining: 'ﺷ', Medium: 'ﺸ', Final: 'ﺶ'}, // sheen
+	{Unicode: 'ص', Isolated: 'ﺹ', Beggining: 'ﺻ', Medium: 'ﺼ', Final: 'ﺺ'}, // sad
+	{Unicode: 'ض', Isolated: 'ﺽ', Beggining: 'ﺿ', Medium: 'ﻀ', Final: 'ﺾ'}, // dad
+	{Unicode: 'ط', Isolated: 'ﻁ', Beggining: 'ﻃ', Medium: 'ﻄ', Final: 'ﻂ'}, // tah
+	{Unicode: 'ظ', Isolated: 'ﻅ', Beggining: 'ﻇ', Medium: 'ﻈ', Final: 'ﻆ'}, // zah
+	{Unicode: 'ع', Isolated: 'ﻉ', Beggining: 'ﻋ', Medium: 'ﻌ', Final: 'ﻊ'}, // ain
+	{Unicode: 'غ', Isolated: 'ﻍ', Beggining: 'ﻏ', Medium: 'ﻐ', Final: 'ﻎ'}, // ghain
+	{Unicode: 'ف', Isolated: 'ﻑ', Beggining: 'ﻓ', Medium: 'ﻔ', Final: 'ﻒ'}, // feh
+	{Unicode: 'ق', Isolated: 'ﻕ', Beggining: 'ﻗ', Medium: 'ﻘ', Final: 'ﻖ'}, // qaf
+	{Unicode: 'ك', Isolated: 'ﻙ', Beggining: 'ﻛ', Medium: 'ﻜ', Final: 'ﻚ'}, // kaf
+	{Unicode: 'ل', Isolated: 'ﻝ', Beggining: 'ﻟ', Medium: 'ﻠ', Final: 'ﻞ'}, // lam
+	{Unicode: 'م', Isolated: 'ﻡ', Beggining: 'ﻣ', Medium: 'ﻤ', Final: 'ﻢ'}, // meem
+	{Unicode: 'ن', Isolated: 'ﻥ', Beggining: 'ﻧ', Medium: 'ﻨ', Final: 'ﻦ'}, // noon
+	{Unicode: 'ه', Isolated: 'ﻩ', Beggining: 'ﻫ', Medium: 'ﻬ', Final: 'ﻪ'}, // heh
+	{Unicode: 'و', Isolated: 'ﻭ', Final: 'ﻮ'},                              // waw
+	{Unicode: 'ى', Isolated: 'ﻯ', Final: 'ﻰ'},                              // alef maksura
+	{Unicode: 'ي', Isolated: 'ﻱ', Beggining: 'ﻳ', Medium: 'ﻴ', Final: 'ﻲ'}, // yeh
+}
+
+// beggining_after holds the Arabic letters that never connect to a letter
+// following them (alef, teh marbuta, dal, thal, reh, zain, waw, alef
+// maksura and their hamza variants), plus the Persian/Urdu letters with the same behavior
+// (jeh, yeh barree), so the letter after one of them always starts a new
+// connected run.
+var beggining_after = map[Harf]bool{
+	alphabet[1]:       true, // alef madda
+	alphabet[2]:       true, // alef hamza above
+	alphabet[3]:       true, // waw hamza
+	alphabet[4]:       true, // alef hamza below
+	alphabet[6]:       true, // alef
+	alphabet[8]:       true, // teh marbuta
+	alphabet[14]:      true, // dal
+	alphabet[15]:      true, // thal
+	alphabet[16]:      true, // reh
+	alphabet[17]:      true, // zain
+	alphabet[33]:      true, // waw
+	alphabet[34]:      true, // alef maksura
+	persianLetters[2]: true, // jeh
+	urduLetters[3]:    true, // yeh barree
+}
+
+// beginningAfterUnicodes mirrors beggining_after keyed by Harf.Unicode
+// instead of the whole struct, so callers holding a *Harf that points into
+// a table mutated in place by normalizeHarfTable (as Shaper's glyphIndex
+// does) can still test membership: a map[Harf]bool lookup misses once the
+// stored key's zero-valued Beggining/Medium/Final no longer match the
+// mutated table entry, while the Unicode codepoint never changes.
+var beginningAfterUnicodes = buildBeginningAfterUnicodes()
+
+func buildBeginningAfterUnicodes() map[rune]bool {
+	m := make(map[rune]bool, len(beggining_after))
+	for h := range beggining_after {
+		m[h.Unicode] = true
+	}
+	return m
+}
+
+func init() {
+	normalizeHarfTable(alphabet)
+}
+
+// normalizeHarfTable fills in the Beggining/Medium/Final shapes a letter
+// doesn't have of its own (hamza and the non-forward-connecting letters
+// only ever render Isolated or Final) by falling back to the nearest
+// shape that exists, so getCharGlyph's generic Beggining/Medium lookups
+// never resolve to the zero rune.
+func normalizeHarfTable(table []Harf) {
+	for i := range table {
+		h := &table[i]
+		if h.Final == 0 {
+			h.Final = h.Isolated
+		}
+		if h.Beggining == 0 {
+			h.Beggining = h.Isolated
+		}
+		if h.Medium == 0 {
+			h.Medium = h.Final
+		}
+	}
+}
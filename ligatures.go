@@ -0,0 +1,62 @@
+package goarabic
+
+// ligaturePlaceholder follows every lam-alef ligature glyph ApplyLigatures
+// emits, so that downstream rune-counting (SmartLength, line-width
+// accounting) sees the same number of runes as the unligated text.
+const ligaturePlaceholder = '\u200b'
+
+// lamAlefLigature holds the isolated and final presentation forms of a
+// lam+alef-variant ligature, keyed by the alef variant's Unicode codepoint.
+type lamAlefLigature struct {
+	alefUnicode rune
+	isolated    rune
+	final       rune
+}
+
+var lamAlefLigatures = []lamAlefLigature{
+	{alefUnicode: 'ا', isolated: 'ﻻ', final: 'ﻼ'}, // lam + alef
+	{alefUnicode: 'أ', isolated: 'ﻷ', final: 'ﻸ'}, // lam + alef hamza above
+	{alefUnicode: 'إ', isolated: 'ﻹ', final: 'ﻺ'}, // lam + alef hamza below
+	{alefUnicode: 'آ', isolated: 'ﻵ', final: 'ﻶ'}, // lam + alef madda
+}
+
+var lamHarf = getHarf('ل')
+
+func lamAlefLigatureFor(alef rune) (lamAlefLigature, bool) {
+	for _, l := range lamAlefLigatures {
+		if getHarf(l.alefUnicode).equals(alef) {
+			return l, true
+		}
+	}
+	return lamAlefLigature{}, false
+}
+
+// ApplyLigatures walks shaped glyph output (as produced by ToGlyph) and
+// collapses every lam immediately followed by an alef variant into the
+// single mandatory lam-alef ligature glyph, choosing the isolated or final
+// presentation form depending on whether the lam was shaped as
+// Isolated/Beggining (nothing joins into it) or Medium/Final (a previous
+// letter joins into it).
+func ApplyLigatures(glyphs []rune) []rune {
+	out := make([]rune, 0, len(glyphs))
+
+	for i := 0; i < len(glyphs); i++ {
+		current := glyphs[i]
+
+		if i+1 < len(glyphs) && lamHarf.equals(current) {
+			if lig, ok := lamAlefLigatureFor(glyphs[i+1]); ok {
+				if current == lamHarf.Medium || current == lamHarf.Final {
+					out = append(out, lig.final, ligaturePlaceholder)
+				} else {
+					out = append(out, lig.isolated, ligaturePlaceholder)
+				}
+				i++
+				continue
+			}
+		}
+
+		out = append(out, current)
+	}
+
+	return out
+}
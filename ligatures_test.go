@@ -0,0 +1,35 @@
+package goarabic
+
+import "testing"
+
+// TestApplyLigaturesViaToGlyphWithOptions exercises ApplyLigatures through
+// ToGlyphWithOptions (which ligates by default), covering all four lam-alef
+// variants and both the isolated (word-initial lam) and final (lam joined
+// into from a previous letter) ligature forms.
+func TestApplyLigaturesViaToGlyphWithOptions(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"لا", "ﻻ​"},            // lam + alef, isolated form
+		{"الا", "ﺍﻻ​"},          // lam + alef, isolated form (lam after a non-joining alef, not joined into)
+		{"كلام", "ﻛﻼ​ﻡ"},        // lam + alef, final form (lam joined into by kaf)
+		{"لأحمد", "ﻷ​ﺣﻤﺪ"},      // lam + alef hamza above
+		{"لإبراهيم", "ﻹ​ﺑﺮﺍﻫﻴﻢ"}, // lam + alef hamza below
+		{"لآدم", "ﻵ​ﺩﻡ"},        // lam + alef madda
+	}
+
+	for _, c := range cases {
+		if got := ToGlyphWithOptions(c.in, Options{}); got != c.want {
+			t.Errorf("ToGlyphWithOptions(%q, {}) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestApplyLigaturesDisabled(t *testing.T) {
+	in := "لا"
+	want := "ﻟﺎ"
+	if got := ToGlyphWithOptions(in, Options{DisableLigatures: true}); got != want {
+		t.Errorf("ToGlyphWithOptions(%q, {DisableLigatures: true}) = %q, want %q", in, got, want)
+	}
+}
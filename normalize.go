@@ -0,0 +1,75 @@
+package goarabic
+
+import "strings"
+
+// NormalizeOptions toggles the individual transformations Normalize applies,
+// so callers can compose only the ones their pipeline needs.
+type NormalizeOptions struct {
+	// StripTashkeel removes Arabic vowel marks (see RemoveTashkeel).
+	StripTashkeel bool
+	// RemoveTatweel removes the Tatweel elongation character (see RemoveTatweel).
+	RemoveTatweel bool
+	// UnifyAlef collapses hamza-bearing alifs (أ إ آ ٱ) to bare ا.
+	UnifyAlef bool
+	// UnifyYa folds alef maksura ى to ya ي.
+	UnifyYa bool
+	// UnifyHa folds teh marbuta ة to heh ه.
+	UnifyHa bool
+	// FoldDigits folds Eastern Arabic (٠-٩) and Persian (۰-۹) digits to ASCII 0-9.
+	FoldDigits bool
+}
+
+// DefaultNormalizeOptions enables every transformation Normalize supports,
+// which matches what most Arabic search/indexing pipelines expect.
+var DefaultNormalizeOptions = NormalizeOptions{
+	StripTashkeel: true,
+	RemoveTatweel: true,
+	UnifyAlef:     true,
+	UnifyYa:       true,
+	UnifyHa:       true,
+	FoldDigits:    true,
+}
+
+// Normalize canonicalizes common Arabic character variants the way
+// search/indexing and NLP pipelines expect: it collapses hamza-bearing
+// alifs to bare alef, teh marbuta to heh, alef maksura to ya, removes
+// tatweel, strips tashkeel, and folds Eastern Arabic/Persian digits to
+// ASCII, using DefaultNormalizeOptions. Use NormalizeWithOptions to
+// compose only a subset of these transformations.
+func Normalize(s string) string {
+	return NormalizeWithOptions(s, DefaultNormalizeOptions)
+}
+
+// NormalizeWithOptions is Normalize with the set of transformations
+// controlled by opts.
+func NormalizeWithOptions(s string, opts NormalizeOptions) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for _, r := range s {
+		if opts.StripTashkeel && tashkeel[r] {
+			continue
+		}
+
+		if opts.RemoveTatweel && TATWEEL.equals(r) {
+			continue
+		}
+
+		switch {
+		case opts.UnifyAlef && (r == 'أ' || r == 'إ' || r == 'آ' || r == 'ٱ'):
+			r = 'ا'
+		case opts.UnifyYa && r == 'ى':
+			r = 'ي'
+		case opts.UnifyHa && r == 'ة':
+			r = 'ه'
+		case opts.FoldDigits && r >= '٠' && r <= '٩':
+			r = '0' + (r - '٠')
+		case opts.FoldDigits && r >= '۰' && r <= '۹':
+			r = '0' + (r - '۰')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package goarabic
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"hamza-bearing alifs unify to bare alef", "أحمد إبراهيم آدم ٱلرحمن", "احمد ابراهيم ادم الرحمن"},
+		{"teh marbuta folds to heh", "مدرسة", "مدرسه"},
+		{"alef maksura folds to ya", "على", "علي"},
+		{"tatweel is removed", "الـارض", "الارض"},
+		{"tashkeel is stripped", "مَرْحَبًا", "مرحبا"},
+		{"eastern Arabic digits fold to ASCII", "١٢٣", "123"},
+		{"Persian digits fold to ASCII", "۱۲۳", "123"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Normalize(c.in); got != c.want {
+				t.Errorf("Normalize(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeWithOptionsSubset(t *testing.T) {
+	opts := NormalizeOptions{UnifyAlef: true}
+	in := "أحمد علي"
+	want := "احمد علي"
+	if got := NormalizeWithOptions(in, opts); got != want {
+		t.Errorf("NormalizeWithOptions(%q, %+v) = %q, want %q", in, opts, got, want)
+	}
+
+	// UnifyYa is disabled, so alef maksura must survive untouched.
+	in2 := "على"
+	if got := NormalizeWithOptions(in2, opts); got != in2 {
+		t.Errorf("NormalizeWithOptions(%q, %+v) = %q, want unchanged %q", in2, opts, got, in2)
+	}
+}
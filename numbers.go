@@ -0,0 +1,192 @@
+package goarabic
+
+// SpellNumberOptions controls gender agreement and an optional currency
+// suffix when spelling out a number with SpellNumber.
+type SpellNumberOptions struct {
+	// Feminine selects the feminine forms of "one" and "two" and of the
+	// ones digit in compound numbers (e.g. for counting feminine nouns).
+	Feminine bool
+	// Currency, if non-empty, is appended as a suffix word, e.g. "ريال".
+	Currency string
+}
+
+var onesMasculine = [10]string{"", "واحد", "اثنان", "ثلاثة", "أربعة", "خمسة", "ستة", "سبعة", "ثمانية", "تسعة"}
+var onesFeminine = [10]string{"", "واحدة", "اثنتان", "ثلاث", "أربع", "خمس", "ست", "سبع", "ثماني", "تسع"}
+
+var teensMasculine = [10]string{"عشرة", "أحد عشر", "اثنا عشر", "ثلاثة عشر", "أربعة عشر", "خمسة عشر", "ستة عشر", "سبعة عشر", "ثمانية عشر", "تسعة عشر"}
+var teensFeminine = [10]string{"عشرة", "إحدى عشرة", "اثنتا عشرة", "ثلاث عشرة", "أربع عشرة", "خمس عشرة", "ست عشرة", "سبع عشرة", "ثماني عشرة", "تسع عشرة"}
+
+var tens = [10]string{"", "", "عشرون", "ثلاثون", "أربعون", "خمسون", "ستون", "سبعون", "ثمانون", "تسعون"}
+
+var hundreds = [10]string{"", "مائة", "مئتان", "ثلاثمائة", "أربعمائة", "خمسمائة", "ستمائة", "سبعمائة", "ثمانمائة", "تسعمائة"}
+
+// scaleWord picks the singular/dual/plural/accusative form of a scale word
+// (thousand, million, billion) for the given count, following the standard
+// Arabic rule: 1 singular, 2 dual, 3-10 plural, 11-99 singular accusative.
+type scale struct {
+	singular   string
+	dual       string
+	plural     string
+	accusative string
+}
+
+var scales = []scale{
+	{"", "", "", ""}, // units, unused
+	{"ألف", "ألفان", "آلاف", "ألفًا"},
+	{"مليون", "مليونان", "ملايين", "مليونًا"},
+	{"مليار", "ملياران", "مليارات", "مليارًا"},
+}
+
+func scaleWord(s scale, count int64) string {
+	switch {
+	case count == 1:
+		return s.singular
+	case count == 2:
+		return s.dual
+	case count%100 >= 3 && count%100 <= 10:
+		return s.plural
+	default:
+		return s.accusative
+	}
+}
+
+// spellGroup spells a number in [0, 999] out in words.
+func spellGroup(n int, feminine bool) string {
+	if n == 0 {
+		return ""
+	}
+
+	ones := onesMasculine
+	teens := teensMasculine
+	if feminine {
+		ones = onesFeminine
+		teens = teensFeminine
+	}
+
+	var parts []string
+
+	h := n / 100
+	rest := n % 100
+	if h > 0 {
+		parts = append(parts, hundreds[h])
+	}
+
+	switch {
+	case rest >= 11 && rest <= 19:
+		parts = append(parts, teens[rest-10])
+	case rest == 10:
+		parts = append(parts, teens[0])
+	case rest > 0:
+		d := rest / 10
+		o := rest % 10
+		if o > 0 && d > 0 {
+			parts = append(parts, ones[o], tens[d])
+		} else if o > 0 {
+			parts = append(parts, ones[o])
+		} else {
+			parts = append(parts, tens[d])
+		}
+	}
+
+	result := ""
+	for i, p := range parts {
+		if i == 0 {
+			result = p
+			continue
+		}
+		result += " و" + p
+	}
+	return result
+}
+
+// maxSpellableNumber is the largest magnitude SpellNumber/SpellNumberWithOptions
+// can spell out: scales only go up to billions, and spellGroup only handles a
+// 0-999 count per scale, so 999 billion is the ceiling. Larger magnitudes are
+// clamped to it rather than spelled incorrectly (or indexed out of range).
+const maxSpellableNumber = 999_999_999_999
+
+// SpellNumber converts n into its Arabic spelled-out form, e.g.
+// 125 -> "مائة وخمسة وعشرون". Magnitudes above maxSpellableNumber are clamped
+// to it. Use SpellNumberWithOptions to control gender agreement or append a
+// currency suffix.
+func SpellNumber(n int64) string {
+	return SpellNumberWithOptions(n, SpellNumberOptions{})
+}
+
+// SpellNumberWithOptions is SpellNumber with gender agreement and an
+// optional currency suffix controlled by opts.
+func SpellNumberWithOptions(n int64, opts SpellNumberOptions) string {
+	if n == 0 {
+		return appendCurrency("صفر", opts.Currency)
+	}
+
+	negative := n < 0
+	if negative {
+		n = -n
+	}
+	if n > maxSpellableNumber || n < 0 { // n < 0 only for math.MinInt64, whose negation overflows
+		n = maxSpellableNumber
+	}
+
+	// Split into groups of 3 digits, from billions down to units.
+	billions := n / 1_000_000_000
+	millions := (n / 1_000_000) % 1000
+	thousands := (n / 1000) % 1000
+	units := n % 1000
+
+	var parts []string
+
+	if billions > 0 {
+		parts = append(parts, spellScaleGroup(billions, scales[3], false))
+	}
+	if millions > 0 {
+		parts = append(parts, spellScaleGroup(millions, scales[2], false))
+	}
+	if thousands > 0 {
+		parts = append(parts, spellScaleGroup(thousands, scales[1], false))
+	}
+	if units > 0 || len(parts) == 0 {
+		if g := spellGroup(int(units), opts.Feminine); g != "" {
+			parts = append(parts, g)
+		}
+	}
+
+	result := ""
+	for i, p := range parts {
+		if i == 0 {
+			result = p
+			continue
+		}
+		result += " و" + p
+	}
+
+	if negative {
+		result = "سالب " + result
+	}
+
+	return appendCurrency(result, opts.Currency)
+}
+
+// spellScaleGroup spells a 1-999 count followed by its scale word (thousand,
+// million or billion), e.g. 2 -> "ألفان", 5 -> "خمسة آلاف", 125 -> "مائة
+// وخمسة وعشرون ألفًا". SpellNumberWithOptions always passes feminine=false,
+// since scale words (ألف، مليون، مليار) are masculine nouns.
+func spellScaleGroup(count int64, s scale, feminine bool) string {
+	if count == 1 {
+		return s.singular
+	}
+	if count == 2 {
+		return s.dual
+	}
+
+	g := spellGroup(int(count), feminine)
+	return g + " " + scaleWord(s, count)
+}
+
+func appendCurrency(s, currency string) string {
+	if currency == "" {
+		return s
+	}
+	return s + " " + currency
+}
+
@@ -0,0 +1,65 @@
+package goarabic
+
+import "testing"
+
+func TestSpellNumber(t *testing.T) {
+	cases := []struct {
+		n    int64
+		want string
+	}{
+		{0, "صفر"},
+		{1, "واحد"},
+		{2, "اثنان"},
+		{10, "عشرة"},
+		{11, "أحد عشر"},
+		{21, "واحد وعشرون"},
+		{100, "مائة"},
+		{125, "مائة وخمسة وعشرون"},
+		{200, "مئتان"},
+		{999, "تسعمائة وتسعة وتسعون"},
+		{1000, "ألف"},
+		{1001, "ألف وواحد"},
+		{2000, "ألفان"},
+		{5000, "خمسة آلاف"},
+		{11000, "أحد عشر ألفًا"},
+		{1_000_000, "مليون"},
+		{2_000_000, "مليونان"},
+		{1_000_000_000, "مليار"},
+		{3_000_000_000, "ثلاثة مليارات"},
+		{-42, "سالب اثنان وأربعون"},
+	}
+
+	for _, c := range cases {
+		if got := SpellNumber(c.n); got != c.want {
+			t.Errorf("SpellNumber(%d) = %q, want %q", c.n, got, c.want)
+		}
+	}
+}
+
+// TestSpellNumberClampsOutOfRange guards against the panic previously
+// triggered by magnitudes whose billions group exceeds spellGroup's 0-999
+// range (e.g. SpellNumber(2_000_000_000_000) indexed hundreds[20]).
+func TestSpellNumberClampsOutOfRange(t *testing.T) {
+	atCeiling := SpellNumber(maxSpellableNumber)
+	beyond := SpellNumber(maxSpellableNumber + 1)
+	wayBeyond := SpellNumber(2_000_000_000_000)
+
+	if beyond != atCeiling {
+		t.Errorf("SpellNumber(maxSpellableNumber+1) = %q, want the clamped %q", beyond, atCeiling)
+	}
+	if wayBeyond != atCeiling {
+		t.Errorf("SpellNumber(2_000_000_000_000) = %q, want the clamped %q", wayBeyond, atCeiling)
+	}
+}
+
+func TestSpellNumberWithOptions(t *testing.T) {
+	if got, want := SpellNumberWithOptions(1, SpellNumberOptions{Feminine: true}), "واحدة"; got != want {
+		t.Errorf("feminine 1 = %q, want %q", got, want)
+	}
+	if got, want := SpellNumberWithOptions(21, SpellNumberOptions{Feminine: true}), "واحدة وعشرون"; got != want {
+		t.Errorf("feminine 21 = %q, want %q", got, want)
+	}
+	if got, want := SpellNumberWithOptions(5, SpellNumberOptions{Currency: "ريال"}), "خمسة ريال"; got != want {
+		t.Errorf("currency 5 = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package goarabic
+
+// persianLetters holds the presentation-form quadruples for the Persian-only
+// letters that are not part of the 28-letter Arabic alphabet: peh, tcheh,
+// jeh and gaf.
+var persianLetters = []Harf{
+	{Unicode: 'پ', Isolated: 'ﭖ', Beggining: 'ﭘ', Medium: 'ﭙ', Final: 'ﭗ'}, // peh
+	{Unicode: 'چ', Isolated: 'ﭺ', Beggining: 'ﭼ', Medium: 'ﭽ', Final: 'ﭻ'}, // tcheh
+	{Unicode: 'ژ', Isolated: 'ﮊ', Final: 'ﮋ'},                              // jeh (non-joining, like reh)
+	{Unicode: 'گ', Isolated: 'ﮒ', Beggining: 'ﮔ', Medium: 'ﮕ', Final: 'ﮓ'}, // gaf
+}
+
+// urduLetters holds the presentation-form quadruples for the Urdu-only
+// letters that are not part of the 28-letter Arabic alphabet: keheh, farsi
+// yeh, heh goal and yeh barree.
+var urduLetters = []Harf{
+	{Unicode: 'ک', Isolated: 'ﮎ', Beggining: 'ﮐ', Medium: 'ﮑ', Final: 'ﮏ'}, // keheh
+	{Unicode: 'ی', Isolated: 'ﯼ', Beggining: 'ﯾ', Medium: 'ﯿ', Final: 'ﯽ'}, // farsi yeh
+	{Unicode: 'ہ', Isolated: 'ﮦ', Beggining: 'ﮨ', Medium: 'ﮩ', Final: 'ﮧ'}, // heh goal
+	{Unicode: 'ے', Isolated: 'ﮮ', Final: 'ﮯ'},                              // yeh barree (non-joining, like alef maksura)
+}
+
+func init() {
+	normalizeHarfTable(persianLetters)
+	normalizeHarfTable(urduLetters)
+}
+
+// Options controls which extra presentation-form tables ToGlyphWithOptions
+// joins on top of the standard 28-letter Arabic alphabet.
+type Options struct {
+	// Persian enables shaping of the Persian-only letters (peh, tcheh, jeh, gaf).
+	Persian bool
+	// Urdu enables shaping of the Urdu-only letters (keheh, farsi yeh, heh goal, yeh barree).
+	Urdu bool
+	// DisableLigatures turns off the mandatory lam-alef ligature pass (see
+	// ApplyLigatures), for callers targeting terminals without FBxx coverage.
+	DisableLigatures bool
+	// MaxCharsPerLine bounds how many characters a Shaper's WriteTo puts on
+	// one output line when no hard line break is seen; lines break only at
+	// whitespace. Zero uses defaultMaxCharsPerLine.
+	MaxCharsPerLine int
+}
+
+// ToGlyphWithOptions behaves like ToGlyph but additionally shapes
+// Persian and/or Urdu letters when enabled via opts, so Farsi/Urdu text
+// joins correctly through the same pipeline FixBidiText uses. Unlike
+// ToGlyph, it also applies the lam-alef ligature pass (see ApplyLigatures)
+// unless opts.DisableLigatures is set.
+func ToGlyphWithOptions(text string, opts Options) string {
+	alpha := alphabet
+	if opts.Persian {
+		alpha = append(append([]Harf{}, alpha...), persianLetters...)
+	}
+	if opts.Urdu {
+		alpha = append(append([]Harf{}, alpha...), urduLetters...)
+	}
+
+	var prev, next rune
+
+	runes := []rune(text)
+	length := len(runes)
+	newText := make([]rune, 0, length)
+
+	for i, current := range runes {
+		if (i - 1) < 0 {
+			prev = 0
+		} else {
+			prev = runes[i-1]
+		}
+
+		if (i + 1) <= length-1 {
+			next = runes[i+1]
+		} else {
+			next = 0
+		}
+
+		newText = append(newText, getCharGlyphFrom(alpha, prev, current, next))
+	}
+
+	if !opts.DisableLigatures {
+		newText = ApplyLigatures(newText)
+	}
+
+	return string(newText)
+}
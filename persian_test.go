@@ -0,0 +1,44 @@
+package goarabic
+
+import "testing"
+
+func TestToGlyphWithOptionsPersian(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"پدر", "ﭘﺪﺭ"},
+		{"ژاله", "ﮊﺍﻟﻪ"},
+		// jeh never connects forward, so the beh after it must take the
+		// initial form (U+FE91), not the medial form.
+		{"اژبا", "ﺍﮊﺑﺎ"},
+	}
+
+	for _, c := range cases {
+		if got := ToGlyphWithOptions(c.in, Options{Persian: true}); got != c.want {
+			t.Errorf("ToGlyphWithOptions(%q, {Persian: true}) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestToGlyphWithOptionsUrdu(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"کتاب", "ﮐﺘﺎﺏ"},
+		{"ہم", "ﮨﻢ"},
+		// a standalone farsi yeh must render as U+FBFC, not the alef
+		// maksura isolated form U+FEEF.
+		{"ی", "ﯼ"},
+		// the word-initial beh joins into yeh barree, so it takes the
+		// beginning form, and yeh barree itself the final form.
+		{"بے", "ﺑﮯ"},
+	}
+
+	for _, c := range cases {
+		if got := ToGlyphWithOptions(c.in, Options{Urdu: true}); got != c.want {
+			t.Errorf("ToGlyphWithOptions(%q, {Urdu: true}) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
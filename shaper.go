@@ -0,0 +1,180 @@
+package goarabic
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// defaultMaxCharsPerLine caps how many characters Shaper's WriteTo puts on
+// one output line when no hard line break (U+000A, U+2028, U+2029) is seen
+// and Options.MaxCharsPerLine is unset.
+const defaultMaxCharsPerLine = 1024
+
+// Shaper runs Arabic shaping and bidi reordering behind an io.Writer: it
+// precomputes a rune->Harf index once at construction, so per-rune shaping
+// is O(1) instead of the O(|alphabet|) rescans ToGlyph does, and WriteTo
+// writes out one visual line at a time. Write keeps everything written
+// since the last WriteTo in memory; nothing is shaped or flushed before
+// WriteTo is called.
+type Shaper struct {
+	opts       Options
+	glyphIndex map[rune]*Harf
+	buf        []rune
+}
+
+// NewShaper builds a Shaper configured by opts, precomputing a rune->Harf
+// index over the standard alphabet plus any Persian/Urdu letters opts
+// enables.
+func NewShaper(opts Options) *Shaper {
+	alpha := alphabet
+	if opts.Persian {
+		alpha = append(append([]Harf{}, alpha...), persianLetters...)
+	}
+	if opts.Urdu {
+		alpha = append(append([]Harf{}, alpha...), urduLetters...)
+	}
+
+	index := make(map[rune]*Harf, len(alpha))
+	for i := range alpha {
+		h := &alpha[i]
+		for _, r := range []rune{h.Unicode, h.Isolated, h.Beggining, h.Medium, h.Final} {
+			if r != 0 {
+				index[r] = h
+			}
+		}
+	}
+
+	return &Shaper{opts: opts, glyphIndex: index}
+}
+
+// Write implements io.Writer, buffering p for shaping by WriteTo. It never
+// returns a short write or an error.
+func (s *Shaper) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, []rune(string(p))...)
+	return len(p), nil
+}
+
+// WriteTo implements io.WriterTo: it shapes and bidi-reorders the buffered
+// input, flushing one visual line at a time to w as soon as a hard line
+// break is seen or MaxCharsPerLine is reached, then clears the buffer so
+// the Shaper can be reused for the next chunk written to it. A
+// MaxCharsPerLine flush only ever happens at a whitespace boundary, never
+// inside a word - shaping a word needs its neighbors on both sides, so
+// cutting mid-word would corrupt the letter joining at the cut. This means
+// a single word longer than MaxCharsPerLine is flushed whole as one
+// over-long line rather than split.
+func (s *Shaper) WriteTo(w io.Writer) (int64, error) {
+	maxChars := s.opts.MaxCharsPerLine
+	if maxChars <= 0 {
+		maxChars = defaultMaxCharsPerLine
+	}
+
+	var written int64
+	lineStart := 0
+	lastBoundary := -1 // index just past the most recent whitespace rune since lineStart, or -1 if none yet
+
+	flush := func(end int) error {
+		shaped := s.shapeLine(string(s.buf[lineStart:end]))
+		n, err := io.WriteString(w, ReorderBidi(shaped, Auto)+"\n")
+		written += int64(n)
+		return err
+	}
+
+	for i, r := range s.buf {
+		if r == '\n' || r == '\u2028' || r == '\u2029' {
+			if err := flush(i); err != nil {
+				return written, err
+			}
+			lineStart, lastBoundary = i+1, -1
+			continue
+		}
+
+		if unicode.IsSpace(r) {
+			lastBoundary = i + 1
+		}
+
+		if i+1-lineStart >= maxChars && lastBoundary > lineStart {
+			if err := flush(lastBoundary); err != nil {
+				return written, err
+			}
+			lineStart, lastBoundary = lastBoundary, -1
+		}
+	}
+
+	if lineStart < len(s.buf) {
+		if err := flush(len(s.buf)); err != nil {
+			return written, err
+		}
+	}
+
+	s.buf = s.buf[:0]
+	return written, nil
+}
+
+// shapeLine runs word-level Arabic shaping over line using the Shaper's
+// precomputed glyph index, leaving numeric and non-Arabic words untouched.
+func (s *Shaper) shapeLine(line string) string {
+	words := strings.Fields(line)
+	for i, word := range words {
+		for _, r := range word {
+			if isArabicLetter(r) {
+				words[i] = s.shapeWord(word)
+				break
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func (s *Shaper) shapeWord(word string) string {
+	runes := []rune(word)
+	length := len(runes)
+	out := make([]rune, 0, length)
+
+	for i, current := range runes {
+		var prev, next rune
+		if i > 0 {
+			prev = runes[i-1]
+		}
+		if i+1 < length {
+			next = runes[i+1]
+		}
+		out = append(out, s.glyphFor(prev, current, next))
+	}
+
+	if !s.opts.DisableLigatures {
+		out = ApplyLigatures(out)
+	}
+
+	return string(out)
+}
+
+// glyphFor is getCharGlyphFrom using the Shaper's O(1) glyphIndex instead
+// of a linear scan of the alphabet table.
+func (s *Shaper) glyphFor(previousChar, currentChar, nextChar rune) rune {
+	prevHarf, previousIn := s.glyphIndex[previousChar]
+	_, nextIn := s.glyphIndex[nextChar]
+
+	h, in := s.glyphIndex[currentChar]
+	if !in {
+		return currentChar
+	}
+
+	switch {
+	case previousIn && nextIn:
+		if beginningAfterUnicodes[prevHarf.Unicode] {
+			return h.Beggining
+		}
+		return h.Medium
+	case nextIn:
+		return h.Beggining
+	case previousIn:
+		if beginningAfterUnicodes[prevHarf.Unicode] {
+			return h.Isolated
+		}
+		return h.Final
+	default:
+		return h.Isolated
+	}
+}
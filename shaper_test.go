@@ -0,0 +1,63 @@
+package goarabic
+
+import (
+	"bytes"
+	"testing"
+)
+
+func writeAndShape(t *testing.T, opts Options, text string) string {
+	t.Helper()
+	s := NewShaper(opts)
+	if _, err := s.Write([]byte(text)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := s.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	return buf.String()
+}
+
+func TestShaperWriteTo(t *testing.T) {
+	cases := []struct {
+		name string
+		opts Options
+		text string
+		want string
+	}{
+		{"RTL words, default MaxCharsPerLine", Options{}, "محمد علي", "ﻲﻠﻋ ﺪﻤﺤﻣ\n"},
+		{"mixed LTR/RTL words", Options{}, "hello محمد world", "hello ﺪﻤﺤﻣ world\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := writeAndShape(t, c.opts, c.text); got != c.want {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+// TestShaperWriteToNeverSplitsAWord guards against the regression where
+// flushing at MaxCharsPerLine mid-word shaped each half with no context
+// across the boundary, e.g. NewShaper(Options{MaxCharsPerLine: 2}) on
+// "محمد" used to produce "ﺢﻣ\nﺪﻣ\n" (two corrupted halves) instead of
+// shaping the whole word like ToGlyph does. A flush must only happen at a
+// whitespace boundary.
+func TestShaperWriteToNeverSplitsAWord(t *testing.T) {
+	// A single word longer than MaxCharsPerLine is flushed whole, as one
+	// over-long line, rather than split mid-word.
+	got := writeAndShape(t, Options{MaxCharsPerLine: 2}, "محمد")
+	want := ReorderBidi(ToGlyph("محمد"), Auto) + "\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// A small MaxCharsPerLine over several words still flushes once per
+	// word, never mid-word, each shaped correctly.
+	got = writeAndShape(t, Options{MaxCharsPerLine: 6}, "محمد علي كريم")
+	want = "ﺪﻤﺤﻣ\nﻲﻠﻋ\nﻢﻳﺮﻛ\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -1,11 +1,6 @@
 // Package goarabic contains utility functions for working with Arabic strings.
 package goarabic
 
-import (
-	"strings"
-	"unicode"
-)
-
 // Reverse returns its argument string reversed rune-wise left to right.
 func Reverse(s string) string {
 	r := []rune(s)
@@ -22,7 +17,7 @@ func SmartLength(s *string) int {
 	length := 0
 
 	for _, value := range *s {
-		if tashkeel[value] {
+		if tashkeel[value] || value == ligaturePlaceholder {
 			continue
 		}
 		length++
@@ -63,11 +58,18 @@ func RemoveTatweel(s string) string {
 }
 
 func getCharGlyph(previousChar, currentChar, nextChar rune) rune {
+	return getCharGlyphFrom(alphabet, previousChar, currentChar, nextChar)
+}
+
+// getCharGlyphFrom is getCharGlyph generalized over the alphabet table to
+// shape against, so ToGlyphWithOptions can join in the Persian/Urdu letters
+// without duplicating the shaping logic.
+func getCharGlyphFrom(alpha []Harf, previousChar, currentChar, nextChar rune) rune {
 	glyph := currentChar
 	previousIn := false // in the Arabic Alphabet or not
 	nextIn := false     // in the Arabic Alphabet or not
 
-	for _, s := range alphabet {
+	for _, s := range alpha {
 		if s.equals(previousChar) { // previousChar in the Arabic Alphabet ?
 			previousIn = true
 		}
@@ -77,7 +79,7 @@ func getCharGlyph(previousChar, currentChar, nextChar rune) rune {
 		}
 	}
 
-	for _, s := range alphabet {
+	for _, s := range alpha {
 
 		if !s.equals(currentChar) { // currentChar in the Arabic Alphabet ?
 			continue
@@ -86,28 +88,28 @@ func getCharGlyph(previousChar, currentChar, nextChar rune) rune {
 		if previousIn && nextIn { // between two Arabic Alphabet, return the medium glyph
 			for s, _ := range beggining_after {
 				if s.equals(previousChar) {
-					return getHarf(currentChar).Beggining
+					return getHarfFrom(alpha, currentChar).Beggining
 				}
 			}
 
-			return getHarf(currentChar).Medium
+			return getHarfFrom(alpha, currentChar).Medium
 		}
 
 		if nextIn { // beginning (because the previous is not in the Arabic Alphabet)
-			return getHarf(currentChar).Beggining
+			return getHarfFrom(alpha, currentChar).Beggining
 		}
 
 		if previousIn { // final (because the next is not in the Arabic Alphabet)
 			for s, _ := range beggining_after {
 				if s.equals(previousChar) {
-					return getHarf(currentChar).Isolated
+					return getHarfFrom(alpha, currentChar).Isolated
 				}
 			}
-			return getHarf(currentChar).Final
+			return getHarfFrom(alpha, currentChar).Final
 		}
 
 		if !previousIn && !nextIn {
-			return getHarf(currentChar).Isolated
+			return getHarfFrom(alpha, currentChar).Isolated
 		}
 
 	}
@@ -116,7 +118,7 @@ func getCharGlyph(previousChar, currentChar, nextChar rune) rune {
 
 // equals() return if true if the given Arabic char is alphabetically equal to
 // the current Harf regardless its shape (Glyph)
-func (c *Harf) equals(char rune) bool {
+func (c Harf) equals(char rune) bool {
 	switch char {
 	case c.Unicode:
 		return true
@@ -135,7 +137,12 @@ func (c *Harf) equals(char rune) bool {
 
 // getHarf gets the correspondent Harf for the given Arabic char
 func getHarf(char rune) Harf {
-	for _, s := range alphabet {
+	return getHarfFrom(alphabet, char)
+}
+
+// getHarfFrom is getHarf generalized over the alphabet table to look up.
+func getHarfFrom(alpha []Harf, char rune) Harf {
+	for _, s := range alpha {
 		if s.equals(char) {
 			return s
 		}
@@ -162,7 +169,9 @@ func RemoveAllNonArabicChars(text string) string {
 	return string(newText)
 }
 
-// ToGlyph returns the glyph representation of the given text
+// ToGlyph returns the glyph representation of the given text. It never
+// applies the lam-alef ligature pass (see ApplyLigatures); use
+// ToGlyphWithOptions, which ligates by default, to opt into that.
 func ToGlyph(text string) string {
 	var prev, next rune
 
@@ -215,157 +224,3 @@ func RemoveTashkeelExtended(s string) string {
 }
 */
 
-var isArabic map[rune]bool
-
-func fillIsArabicMap() {
-	if isArabic != nil {
-		return
-	}
-	isArabic = make(map[rune]bool)
-	for r := rune(0x0600); r <= rune(0x06FF); r++ {
-		isArabic[r] = true
-	}
-	for r := rune(0x0750); r <= rune(0x077F); r++ {
-		isArabic[r] = true
-	}
-	for r := rune(0x08A0); r <= rune(0x08FF); r++ {
-		isArabic[r] = true
-	}
-	for r := rune(0xFB50); r <= rune(0xFDFF); r++ {
-		isArabic[r] = true
-	}
-	for r := rune(0xFE70); r <= rune(0xFEFF); r++ {
-		isArabic[r] = true
-	}
-	for r := rune(0x10E60); r <= rune(0x10E7F); r++ {
-		isArabic[r] = true
-	}
-}
-
-func FixBidiText(text string, maxCharsPerLine int) string {
-	if len(text) == 0 {
-		return text
-	}
-
-	fillIsArabicMap()
-
-	var lines []string
-	if maxCharsPerLine > 0 {
-		lines = splitIntoLinesByChars(text, maxCharsPerLine)
-	} else {
-		lines = []string{text}
-	}
-
-	var processedLines []string
-
-	for _, line := range lines {
-		words := strings.Fields(line)
-		var processedWords []string
-
-		for _, word := range words {
-			runes := []rune(word)
-			isArabicWord := false
-			isNumericWord := true
-
-			// Check if word contains Arabic characters or is numeric
-			for _, r := range runes {
-				if isArabic[r] {
-					isArabicWord = true
-				}
-				if !isNumeric(r) {
-					isNumericWord = false
-				}
-			}
-
-			switch {
-			case isNumericWord:
-				// Leave numeric words as-is for both Arabic and Western digits
-				processedWords = append(processedWords, word)
-			case isArabicWord:
-				// Process and reverse Arabic words
-				processedWords = append(processedWords, Reverse(ToGlyph(word)))
-			default:
-				// Leave English words as-is
-				processedWords = append(processedWords, word)
-			}
-		}
-
-		// Reverse entire line for RTL flow
-		for i, j := 0, len(processedWords)-1; i < j; i, j = i+1, j-1 {
-			processedWords[i], processedWords[j] = processedWords[j], processedWords[i]
-		}
-
-		// Reverse back consecutive English words
-		start := -1
-		for i := 0; i < len(processedWords); i++ {
-			isEnglish := true
-			for _, r := range []rune(processedWords[i]) {
-				if isArabic[r] || isNumeric(r) {
-					isEnglish = false
-					break
-				}
-			}
-
-			if isEnglish {
-				if start == -1 {
-					start = i
-				}
-			} else {
-				if start != -1 {
-					reverseSlice(processedWords[start:i])
-					start = -1
-				}
-			}
-		}
-
-		if start != -1 {
-			reverseSlice(processedWords[start:])
-		}
-
-		processedLine := strings.Join(processedWords, " ")
-		processedLines = append(processedLines, processedLine)
-	}
-
-	return strings.Join(processedLines, "\n")
-}
-
-// Helper function to check if a rune is a digit (Western or Arabic)
-func isNumeric(r rune) bool {
-	return unicode.IsDigit(r) || (r >= 0x0660 && r <= 0x0669) // Arabic numerals
-}
-func splitIntoLinesByChars(text string, maxChars int) []string {
-	var lines []string
-	var currentLine strings.Builder
-	currentLineCount := 0
-
-	words := strings.Fields(text)
-	for _, word := range words {
-		wordLen := len([]rune(word)) + 1 // +1 for space
-
-		if currentLineCount > 0 && currentLineCount+wordLen > maxChars {
-			lines = append(lines, strings.TrimSpace(currentLine.String()))
-			currentLine.Reset()
-			currentLineCount = 0
-		}
-
-		if currentLineCount == 0 {
-			currentLine.WriteString(word)
-			currentLineCount = len([]rune(word))
-		} else {
-			currentLine.WriteString(" " + word)
-			currentLineCount += wordLen
-		}
-	}
-
-	if currentLine.Len() > 0 {
-		lines = append(lines, strings.TrimSpace(currentLine.String()))
-	}
-
-	return lines
-}
-
-func reverseSlice(slice []string) {
-	for i, j := 0, len(slice)-1; i < j; i, j = i+1, j-1 {
-		slice[i], slice[j] = slice[j], slice[i]
-	}
-}
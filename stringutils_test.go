@@ -0,0 +1,28 @@
+package goarabic
+
+import "testing"
+
+// TestToGlyphDoesNotLigate guards ToGlyph's contract for terminal-constrained
+// callers: it shapes letters but never runs the lam-alef ligature pass (use
+// ToGlyphWithOptions, which ligates by default, for that).
+func TestToGlyphDoesNotLigate(t *testing.T) {
+	if got, want := ToGlyph("لا"), "ﻟﺎ"; got != want {
+		t.Errorf("ToGlyph(%q) = %q, want %q", "لا", got, want)
+	}
+}
+
+func TestToGlyph(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"محمد", "ﻣﺤﻤﺪ"},
+		{"كتاب", "ﻛﺘﺎﺏ"},
+	}
+
+	for _, c := range cases {
+		if got := ToGlyph(c.in); got != c.want {
+			t.Errorf("ToGlyph(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}